@@ -0,0 +1,77 @@
+// Copyright 2025 Google LLC
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// hedgeConfig enables hedged (backup) reads: when a queued MRD read has
+// been outstanding longer than delayFor returns, a duplicate range read is
+// issued on a second MultiRangeDownloader and raced against the first.
+type hedgeConfig struct {
+	// fixedDelay, if nonzero, is used as the hedge delay for every read.
+	// Zero selects an auto-tuned delay from the object's latencyEWMA.
+	fixedDelay time.Duration
+}
+
+func (h *hedgeConfig) delayFor(latency *latencyEWMA) time.Duration {
+	if h.fixedDelay > 0 {
+		return h.fixedDelay
+	}
+	return latency.hedgeDelay()
+}
+
+const (
+	// latencyEWMAAlpha weights how quickly the tracked average reacts to a
+	// new sample.
+	latencyEWMAAlpha = 0.2
+	// defaultHedgeDelay is used for an object's reads until the first read
+	// latency sample has been observed.
+	defaultHedgeDelay = 50 * time.Millisecond
+	// hedgeDelayMultiplier approximates a p99 over the tracked mean without
+	// the bookkeeping of a real quantile sketch.
+	hedgeDelayMultiplier = 2
+)
+
+// latencyEWMA tracks a per-object exponentially-weighted moving average of
+// read latency, used to auto-tune the hedge delay.
+type latencyEWMA struct {
+	mu  sync.Mutex
+	avg float64 // seconds; 0 means no samples yet
+}
+
+func (e *latencyEWMA) observe(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s := d.Seconds()
+	if e.avg == 0 {
+		e.avg = s
+		return
+	}
+	e.avg = latencyEWMAAlpha*s + (1-latencyEWMAAlpha)*e.avg
+}
+
+func (e *latencyEWMA) hedgeDelay() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.avg == 0 {
+		return defaultHedgeDelay
+	}
+	return time.Duration(e.avg * hedgeDelayMultiplier * float64(time.Second))
+}
+
+// latencyTrackerFor returns the shared latencyEWMA for objectName, creating
+// it on first use.
+func latencyTrackerFor(t *threadData, objectName string) *latencyEWMA {
+	if v, ok := t.objectLatencies.Load(objectName); ok {
+		return v.(*latencyEWMA)
+	}
+	v, _ := t.objectLatencies.LoadOrStore(objectName, &latencyEWMA{})
+	return v.(*latencyEWMA)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Google LLC
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyEWMANoSamples(t *testing.T) {
+	var e latencyEWMA
+	if got := e.hedgeDelay(); got != defaultHedgeDelay {
+		t.Fatalf("hedgeDelay() with no samples = %v, want %v", got, defaultHedgeDelay)
+	}
+}
+
+func TestLatencyEWMAConverges(t *testing.T) {
+	var e latencyEWMA
+	const sample = 10 * time.Millisecond
+	for i := 0; i < 200; i++ {
+		e.observe(sample)
+	}
+	got := e.hedgeDelay()
+	want := time.Duration(float64(sample) * hedgeDelayMultiplier)
+	// After many identical samples the average should have converged to
+	// (near enough) the sample value itself.
+	diff := got - want
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff > time.Millisecond {
+		t.Fatalf("hedgeDelay() after convergence = %v, want close to %v", got, want)
+	}
+}
+
+func TestLatencyEWMAReactsToNewSamples(t *testing.T) {
+	var e latencyEWMA
+	e.observe(10 * time.Millisecond)
+	before := e.hedgeDelay()
+	e.observe(100 * time.Millisecond)
+	after := e.hedgeDelay()
+	if after <= before {
+		t.Fatalf("hedgeDelay() did not increase after a slower sample: before=%v after=%v", before, after)
+	}
+}
+
+func TestHedgeConfigDelayFor(t *testing.T) {
+	t.Run("fixed delay overrides latency", func(t *testing.T) {
+		h := &hedgeConfig{fixedDelay: 5 * time.Millisecond}
+		latency := &latencyEWMA{}
+		latency.observe(time.Second)
+		if got := h.delayFor(latency); got != 5*time.Millisecond {
+			t.Fatalf("delayFor() = %v, want 5ms", got)
+		}
+	})
+
+	t.Run("falls back to latency EWMA", func(t *testing.T) {
+		h := &hedgeConfig{}
+		latency := &latencyEWMA{}
+		if got := h.delayFor(latency); got != defaultHedgeDelay {
+			t.Fatalf("delayFor() with no fixed delay and no samples = %v, want %v", got, defaultHedgeDelay)
+		}
+	})
+}
+
+func TestLatencyTrackerForIsSharedPerObject(t *testing.T) {
+	td := &threadData{}
+	a := latencyTrackerFor(td, "obj")
+	b := latencyTrackerFor(td, "obj")
+	if a != b {
+		t.Fatalf("latencyTrackerFor() returned distinct trackers for the same object name")
+	}
+	c := latencyTrackerFor(td, "other")
+	if a == c {
+		t.Fatalf("latencyTrackerFor() returned the same tracker for different object names")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Google LLC
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import (
+	"hash/crc32"
+	"testing"
+)
+
+func TestReadVerifierObserve(t *testing.T) {
+	data := []byte("the quick brown fox jumps over the lazy dog")
+	want := crc32.Checksum(data, crc32cTable)
+
+	t.Run("in-order match", func(t *testing.T) {
+		rv := &readVerifier{objectCRC32C: want, objectSize: int64(len(data))}
+		mismatch := rv.observe(0, data[:10])
+		if mismatch {
+			t.Fatalf("observe() on a partial read reported a mismatch")
+		}
+		mismatch = rv.observe(10, data[10:])
+		if mismatch {
+			t.Fatalf("observe() = true for a fully matching object, want false")
+		}
+		if rv.broken {
+			t.Fatalf("observe() marked a well-ordered verifier broken")
+		}
+	})
+
+	t.Run("checksum mismatch on last chunk", func(t *testing.T) {
+		rv := &readVerifier{objectCRC32C: want + 1, objectSize: int64(len(data))}
+		rv.observe(0, data[:10])
+		if mismatch := rv.observe(10, data[10:]); !mismatch {
+			t.Fatalf("observe() = false for a corrupted object, want true")
+		}
+	})
+
+	t.Run("out-of-order reads disable verification", func(t *testing.T) {
+		rv := &readVerifier{objectCRC32C: want, objectSize: int64(len(data))}
+		if mismatch := rv.observe(10, data[10:]); mismatch {
+			t.Fatalf("observe() on an out-of-order read reported a mismatch")
+		}
+		if !rv.broken {
+			t.Fatalf("observe() on an out-of-order read did not mark the verifier broken")
+		}
+		// Once broken, later observes (even in-order ones) never report a
+		// mismatch - there's no way to know if the already-skipped bytes
+		// actually matched.
+		if mismatch := rv.observe(0, data[:10]); mismatch {
+			t.Fatalf("observe() on a broken verifier reported a mismatch")
+		}
+	})
+
+	t.Run("resets after a full pass to verify the next one", func(t *testing.T) {
+		rv := &readVerifier{objectCRC32C: want, objectSize: int64(len(data))}
+		// First pass (fio's first read of the file): matches, so no mismatch.
+		rv.observe(0, data[:10])
+		if mismatch := rv.observe(10, data[10:]); mismatch {
+			t.Fatalf("observe() = true for the first, matching pass, want false")
+		}
+		// Second pass starts back at offset 0, as a fresh fio loop iteration
+		// would; it must not be treated as an out-of-order read.
+		rv.observe(0, data[:10])
+		if mismatch := rv.observe(10, data[10:]); mismatch {
+			t.Fatalf("observe() = true for a second, still-matching pass, want false")
+		}
+		if rv.broken {
+			t.Fatalf("observe() left the verifier broken after two clean passes")
+		}
+	})
+
+	t.Run("catches a mismatch introduced in a later pass", func(t *testing.T) {
+		rv := &readVerifier{objectCRC32C: want, objectSize: int64(len(data))}
+		rv.observe(0, data[:10])
+		if mismatch := rv.observe(10, data[10:]); mismatch {
+			t.Fatalf("observe() = true for the first, matching pass, want false")
+		}
+		corrupted := append([]byte(nil), data...)
+		corrupted[0] ^= 0xff
+		rv.observe(0, corrupted[:10])
+		if mismatch := rv.observe(10, corrupted[10:]); !mismatch {
+			t.Fatalf("observe() = false for a corrupted second pass, want true")
+		}
+	})
+
+	t.Run("nil verifier is a no-op", func(t *testing.T) {
+		var rv *readVerifier
+		if mismatch := rv.observe(0, data); mismatch {
+			t.Fatalf("observe() on a nil verifier = true, want false")
+		}
+	})
+}
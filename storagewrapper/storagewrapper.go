@@ -11,12 +11,16 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"log/slog"
 	"runtime/cgo"
 	"strings"
+	"sync"
+	"time"
 	"unsafe"
 
 	"cloud.google.com/go/storage"
@@ -39,33 +43,262 @@ func init() {
 func shouldRetry(err error) bool {
 	result := storage.ShouldRetry(err)
 	slog.Debug("ShouldRetry?", "err", err, "result", result)
+	recordRetry(err, result)
 	return result
 }
 
 type iouCompletion struct {
-	iou unsafe.Pointer
-	err error
+	iou           unsafe.Pointer
+	err           error
+	verifyFailure bool
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// errChecksumMismatch distinguishes a failed end-to-end checksum comparison
+// from an ordinary transport/IO error, so callers can surface it as a verify
+// error rather than an IO error.
+var errChecksumMismatch = errors.New("crc32c checksum mismatch")
+
+// readVerifier accumulates CRC32C across the range callbacks of a single
+// full-object read and compares it against the object's recorded CRC32C once
+// every byte has been observed, then resets to verify the next pass - fio
+// reads the same file repeatedly over a job's lifetime. Reads that arrive
+// out of order (so the running checksum can't be computed incrementally)
+// disable verification for the rest of the verifier's lifetime rather than
+// reporting a false mismatch; later passes are not verified either.
+type readVerifier struct {
+	mu             sync.Mutex
+	objectCRC32C   uint32
+	objectSize     int64
+	crc32cHash     uint32
+	expectedOffset int64
+	broken         bool
+}
+
+func newReadVerifier(oh *storage.ObjectHandle) (*readVerifier, error) {
+	attrs, err := oh.Attrs(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &readVerifier{objectCRC32C: attrs.CRC32C, objectSize: attrs.Size}, nil
+}
+
+// observe folds a completed range read [offset, offset+len(p)) into the
+// running checksum and reports whether this read just completed the full
+// object with a checksum mismatch.
+func (rv *readVerifier) observe(offset int64, p []byte) bool {
+	if rv == nil {
+		return false
+	}
+	rv.mu.Lock()
+	defer rv.mu.Unlock()
+	if rv.broken || offset != rv.expectedOffset {
+		rv.broken = true
+		return false
+	}
+	rv.crc32cHash = crc32.Update(rv.crc32cHash, crc32cTable, p)
+	rv.expectedOffset += int64(len(p))
+	if rv.expectedOffset < rv.objectSize {
+		return false
+	}
+	mismatch := rv.crc32cHash != rv.objectCRC32C
+	// This pass just completed; reset so the next pass over the file is
+	// verified too, instead of every read after the first being rejected as
+	// "out of order" and silently disabling verification for good.
+	rv.crc32cHash = 0
+	rv.expectedOffset = 0
+	rv.broken = false
+	return mismatch
 }
 
 type threadData struct {
 	completions       chan iouCompletion
 	reapedCompletions []iouCompletion
 	client            *storage.Client
+	writerConfig      writerConfig
+	lastEvent         iouCompletion
+	// oDirectIdleWindow is how long a pooled O_DIRECT MultiRangeDownloader is
+	// kept open after its last outstanding range completes, so back-to-back
+	// O_DIRECT reads can coalesce onto one stream instead of paying an
+	// open/close RTT per op. Zero selects defaultODirectIdleWindow.
+	oDirectIdleWindow time.Duration
+	// hedge enables hedged MRD reads for this thread; nil disables them.
+	hedge *hedgeConfig
+	// objectLatencies tracks a latencyEWMA per object name, shared by every
+	// mrdFile opened against that object from this thread, to auto-tune the
+	// hedge delay.
+	objectLatencies sync.Map
+}
+
+// defaultODirectIdleWindow is used when GoStorageInitWithConfig isn't given
+// an explicit oDirectIdleMillis.
+const defaultODirectIdleWindow = 100 * time.Microsecond
+
+// writerConfig holds the write-path tunables set via GoStorageInitWithConfig.
+// A zero value preserves the historical GoStorageInit defaults (library
+// defaults for chunk size/retry deadline, unbounded concurrency, no session
+// timeout).
+type writerConfig struct {
+	chunkSize          int64
+	chunkRetryDeadline time.Duration
+	// sessionTimeout bounds the lifetime of a single writerFile's underlying
+	// context, from open to Close - not any individual Write/Flush call,
+	// since storage.Writer's context is fixed for the life of the stream.
+	sessionTimeout time.Duration
+	uploadSem      chan struct{}
 }
 
 type mrdFile struct {
 	completions chan<- iouCompletion
 	mrd         *storage.MultiRangeDownloader
+	verify      *readVerifier
+	oh          *storage.ObjectHandle
+	hedge       *hedgeConfig // nil disables hedged reads
+	latency     *latencyEWMA // non-nil iff hedge != nil
 }
 
 type oDirectMrdFile struct {
 	completions chan<- iouCompletion
 	oh          *storage.ObjectHandle
+	verify      *readVerifier
+	// perOpStream selects the historical "fresh MultiRangeDownloader per op"
+	// behavior, bypassing pool entirely. Kept for A/B benchmarking against
+	// the pooled default.
+	perOpStream bool
+	pool        *odirectPool
+}
+
+// odirectPool coalesces the O_DIRECT reads of a single file handle onto one
+// MultiRangeDownloader: the first enqueue after an idle period opens it,
+// later enqueues attach via Add, and it's closed idleWindow after the last
+// outstanding range completes.
+type odirectPool struct {
+	oh         *storage.ObjectHandle
+	idleWindow time.Duration
+
+	mu        sync.Mutex
+	mrd       *storage.MultiRangeDownloader
+	pending   int
+	idleTimer *time.Timer
+}
+
+func newODirectPool(oh *storage.ObjectHandle, idleWindow time.Duration) *odirectPool {
+	return &odirectPool{oh: oh, idleWindow: idleWindow}
+}
+
+// acquire returns the pool's MultiRangeDownloader, opening one if none is
+// currently live, and marks one more range as outstanding against it. Every
+// acquire must be paired with a release once that range's callback fires.
+func (p *odirectPool) acquire() (*storage.MultiRangeDownloader, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	if p.mrd == nil {
+		mrd, err := p.oh.NewMultiRangeDownloader(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		p.mrd = mrd
+	}
+	p.pending++
+	return p.mrd, nil
+}
+
+func (p *odirectPool) release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending--
+	if p.pending == 0 {
+		p.idleTimer = time.AfterFunc(p.idleWindow, p.closeIdle)
+	}
+}
+
+func (p *odirectPool) closeIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// The timer has already fired, so there's nothing left to Stop; clear it
+	// unconditionally so a later acquire() doesn't try to cancel a dead timer.
+	p.idleTimer = nil
+	if p.pending != 0 || p.mrd == nil {
+		return
+	}
+	if err := p.mrd.Close(); err != nil {
+		slog.Error("odirect pool: failed to close idle MRD", "err", err)
+	}
+	p.mrd = nil
+}
+
+// close tears down the pool unconditionally, e.g. when the file handle is
+// closed while a stream is still open.
+func (p *odirectPool) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.idleTimer != nil {
+		p.idleTimer.Stop()
+		p.idleTimer = nil
+	}
+	if p.mrd == nil {
+		return nil
+	}
+	err := p.mrd.Close()
+	p.mrd = nil
+	return err
 }
 
 type writerFile struct {
 	w                    *storage.Writer
 	flushAfterEveryWrite bool
+	sidecar              *storage.ObjectHandle
+	offset               int64
+	cancel               context.CancelFunc
+	releaseUploadSlot    func()
+	verify               bool
+	crc32cHash           uint32
+}
+
+// uploadStateSuffix names the sidecar object that checkpoints a resumable
+// append session, relative to the object being written.
+const uploadStateSuffix = ".upload-state"
+
+// uploadState is the checkpoint persisted to the sidecar object after every
+// successful Flush, so a later process can resume an append session at the
+// right offset.
+type uploadState struct {
+	Offset int64 `json:"offset"`
+}
+
+func sidecarHandle(bucket *storage.BucketHandle, object string) *storage.ObjectHandle {
+	return bucket.Object(object + uploadStateSuffix)
+}
+
+func readUploadState(oh *storage.ObjectHandle) (*uploadState, error) {
+	r, err := oh.NewReader(context.Background())
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var state uploadState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+func writeUploadState(oh *storage.ObjectHandle, state uploadState) error {
+	w := oh.Retryer(storage.WithPolicy(storage.RetryAlways)).NewWriter(context.Background())
+	if err := json.NewEncoder(w).Encode(state); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
 }
 
 type goFile interface {
@@ -86,24 +319,69 @@ func handle[T any](v uintptr) (T, cgo.Handle, bool) {
 }
 
 func filenameObjectHandle(td uintptr, filename string) (*threadData, *storage.ObjectHandle, error) {
+	t, bh, _, err := filenameBucketObjectHandle(td, filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, object, _ := strings.Cut(filename, "/")
+	return t, bh.Object(object), nil
+}
+
+func filenameBucketObjectHandle(td uintptr, filename string) (*threadData, *storage.BucketHandle, string, error) {
 	bucket, object, ok := strings.Cut(filename, "/")
 	if !ok {
-		return nil, nil, fmt.Errorf("could not extract bucket from filename %v", filename)
+		return nil, nil, "", fmt.Errorf("could not extract bucket from filename %v", filename)
 	}
 
 	t, _, ok := handle[*threadData](td)
 	if !ok {
-		return nil, nil, fmt.Errorf("handle %d not of type *threadData", td)
+		return nil, nil, "", fmt.Errorf("handle %d not of type *threadData", td)
 	}
 
-	return t, t.client.Bucket(bucket).Object(object), nil
+	return t, t.client.Bucket(bucket), object, nil
 }
 
 //export GoStorageInit
 func GoStorageInit(iodepth uint, endpoint_override *C.char) uintptr {
+	return goStorageInit(iodepth, endpoint_override, writerConfig{}, "", 0, nil)
+}
+
+// chunkSize is the GCS Writer.ChunkSize in bytes (0 keeps the library
+// default). chunkRetryDeadlineSeconds is Writer.ChunkRetryDeadline in
+// seconds (0 keeps the library default). maxConcurrentUploads bounds the
+// number of writerFiles that may be uploading at once (0 means unbounded).
+// opTimeoutSeconds bounds how long a single write session's underlying
+// context may run, from open to Close, before it is cancelled (0 means no
+// timeout); it is a session lifetime cap, not a per-request timeout, since
+// storage.Writer's context can't be changed per Write/Flush call.
+// metricsAddr, if non-empty, starts an embedded Prometheus exporter (e.g.
+// ":9090") shared by every thread in this process. oDirectIdleMillis bounds
+// how long a pooled O_DIRECT MultiRangeDownloader idles before it's closed
+// (0 selects defaultODirectIdleWindow).
+//
+//export GoStorageInitWithConfig
+func GoStorageInitWithConfig(iodepth uint, endpoint_override *C.char, chunkSize int64, chunkRetryDeadlineSeconds int64, maxConcurrentUploads uint, opTimeoutSeconds int64, metricsAddr *C.char, oDirectIdleMillis int64, hedgingEnabled bool, hedgeDelayMicros int64) uintptr {
+	cfg := writerConfig{
+		chunkSize:          chunkSize,
+		chunkRetryDeadline: time.Duration(chunkRetryDeadlineSeconds) * time.Second,
+		sessionTimeout:     time.Duration(opTimeoutSeconds) * time.Second,
+	}
+	if maxConcurrentUploads > 0 {
+		cfg.uploadSem = make(chan struct{}, maxConcurrentUploads)
+	}
+	var hedge *hedgeConfig
+	if hedgingEnabled {
+		hedge = &hedgeConfig{fixedDelay: time.Duration(hedgeDelayMicros) * time.Microsecond}
+	}
+	return goStorageInit(iodepth, endpoint_override, cfg, C.GoString(metricsAddr), time.Duration(oDirectIdleMillis)*time.Millisecond, hedge)
+}
+
+func goStorageInit(iodepth uint, endpoint_override *C.char, cfg writerConfig, metricsAddr string, oDirectIdleWindow time.Duration, hedge *hedgeConfig) uintptr {
 	endpoint := C.GoString(endpoint_override)
 	slog.Info("go storage init", "iodepth", iodepth, "endpoint_override", endpoint)
 
+	startMetricsServer(metricsAddr)
+
 	opts := []option.ClientOption{
 		// Client metrics are super verbose on startup, so turn them off.
 		storage.WithDisabledClientMetrics(),
@@ -119,10 +397,16 @@ func GoStorageInit(iodepth uint, endpoint_override *C.char) uintptr {
 	}
 	c.SetRetry(storage.WithErrorFunc(shouldRetry))
 
+	if oDirectIdleWindow <= 0 {
+		oDirectIdleWindow = defaultODirectIdleWindow
+	}
 	td := &threadData{
 		completions:       make(chan iouCompletion, iodepth),
 		reapedCompletions: make([]iouCompletion, 0, iodepth),
 		client:            c,
+		writerConfig:      cfg,
+		oDirectIdleWindow: oDirectIdleWindow,
+		hedge:             hedge,
 	}
 	return uintptr(cgo.NewHandle(td))
 }
@@ -187,6 +471,7 @@ func GoStorageGetEvent(td uintptr) (iou unsafe.Pointer, ok bool) {
 	}
 	v := t.reapedCompletions[len(t.reapedCompletions)-1]
 	t.reapedCompletions = t.reapedCompletions[:len(t.reapedCompletions)-1]
+	t.lastEvent = v
 	ok = true
 	if v.err != nil {
 		slog.Error("get event: reaped completion error", "err", v.err)
@@ -195,18 +480,46 @@ func GoStorageGetEvent(td uintptr) (iou unsafe.Pointer, ok bool) {
 	return v.iou, ok
 }
 
+// GoStorageLastEventWasVerifyFailure reports whether the completion most
+// recently returned by GoStorageGetEvent failed end-to-end checksum
+// verification, as opposed to an ordinary IO error, so fio can surface it as
+// a distinct verify error.
+//
+//export GoStorageLastEventWasVerifyFailure
+func GoStorageLastEventWasVerifyFailure(td uintptr) bool {
+	t, _, ok := handle[*threadData](td)
+	if !ok {
+		slog.Error("last event verify failure: wrong type handle", "td", td)
+		return false
+	}
+	return t.lastEvent.verifyFailure
+}
+
 //export GoStorageOpenReadonly
-func GoStorageOpenReadonly(td uintptr, oDirect bool, filenameCstr *C.char) uintptr {
+func GoStorageOpenReadonly(td uintptr, oDirect bool, oDirectPerOpStream bool, verify bool, filenameCstr *C.char) uintptr {
 	filename := C.GoString(filenameCstr)
-	slog.Debug("go storage open readonly", "td", td, "oDirect", oDirect, "filename", filename)
+	slog.Debug("go storage open readonly", "td", td, "oDirect", oDirect, "oDirectPerOpStream", oDirectPerOpStream, "verify", verify, "filename", filename)
 	t, oh, err := filenameObjectHandle(td, filename)
 	if err != nil {
 		slog.Error("open: error getting *storage.ObjectHandle", "err", err)
 		return 0
 	}
 
+	var rv *readVerifier
+	if verify {
+		rv, err = newReadVerifier(oh)
+		if err != nil {
+			slog.Error("open: failed to fetch attrs for verify mode", "filename", filename, "err", err)
+			return 0
+		}
+	}
+
 	if oDirect {
-		return uintptr(cgo.NewHandle(&oDirectMrdFile{t.completions, oh}))
+		f := &oDirectMrdFile{completions: t.completions, oh: oh, verify: rv, perOpStream: oDirectPerOpStream}
+		if !oDirectPerOpStream {
+			f.pool = newODirectPool(oh, t.oDirectIdleWindow)
+		}
+		return uintptr(cgo.NewHandle(f))
 	}
 
 	mrd, err := oh.NewMultiRangeDownloader(context.Background())
@@ -214,22 +527,141 @@ func GoStorageOpenReadonly(td uintptr, oDirect bool, filenameCstr *C.char) uintp
 		slog.Error("failed MRD open", "filename", filename, "err", err)
 		return 0
 	}
-	return uintptr(cgo.NewHandle(&mrdFile{t.completions, mrd}))
+	f := &mrdFile{completions: t.completions, mrd: mrd, verify: rv, oh: oh, hedge: t.hedge}
+	if t.hedge != nil {
+		f.latency = latencyTrackerFor(t, filename)
+	}
+	return uintptr(cgo.NewHandle(f))
+}
+
+// newWriterFile applies a threadData's writerConfig (chunk size, chunk retry
+// deadline, upload concurrency limit, and op timeout) and returns a ready to
+// use writerFile for oh. When verify is true, Writer.SendCRC32C is set here,
+// before the first Write, since GCS requires it set up front; the actual
+// CRC32C value is filled in at Close, once the full object hash is known.
+func newWriterFile(t *threadData, oh *storage.ObjectHandle, verify bool) *writerFile {
+	cfg := t.writerConfig
+
+	var release func()
+	if cfg.uploadSem != nil {
+		cfg.uploadSem <- struct{}{}
+		release = func() { <-cfg.uploadSem }
+	}
+
+	ctx := context.Background()
+	var cancel context.CancelFunc
+	if cfg.sessionTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, cfg.sessionTimeout)
+	}
+
+	w := oh.Retryer(storage.WithPolicy(storage.RetryAlways)).NewWriter(ctx)
+	w.Append = true
+	if cfg.chunkSize > 0 {
+		w.ChunkSize = int(cfg.chunkSize)
+	}
+	if cfg.chunkRetryDeadline > 0 {
+		w.ChunkRetryDeadline = cfg.chunkRetryDeadline
+	}
+	if verify {
+		w.SendCRC32C = true
+	}
+	return &writerFile{w: w, cancel: cancel, releaseUploadSlot: release, verify: verify}
 }
 
 //export GoStorageOpenWriteonly
-func GoStorageOpenWriteonly(td uintptr, flushAfterEveryWrite bool, filenameCstr *C.char) uintptr {
+func GoStorageOpenWriteonly(td uintptr, flushAfterEveryWrite bool, verify bool, filenameCstr *C.char) uintptr {
 	filename := C.GoString(filenameCstr)
-	slog.Debug("go storage open writeonly", "td", td, "filename", filename)
-	_, oh, err := filenameObjectHandle(td, filename)
+	slog.Debug("go storage open writeonly", "td", td, "filename", filename, "verify", verify)
+	t, bh, object, err := filenameBucketObjectHandle(td, filename)
 	if err != nil {
 		slog.Error("open: error getting *storage.ObjectHandle", "err", err)
 		return 0
 	}
+	oh := bh.Object(object)
+
+	// End-to-end verify relies on CRC32C only being checked at Close/finalize;
+	// in flushAfterEveryWrite mode each chunk is already durably committed
+	// during enqueue, long before Close, so a mismatch could never be
+	// rejected. Verify is only meaningful for single-shot (no-flush) writes.
+	if verify && flushAfterEveryWrite {
+		slog.Error("open: verify is not supported with flushAfterEveryWrite", "filename", filename)
+		return 0
+	}
 
-	w := oh.Retryer(storage.WithPolicy(storage.RetryAlways)).NewWriter(context.Background())
-	w.Append = true
-	return uintptr(cgo.NewHandle(&writerFile{w, flushAfterEveryWrite}))
+	wf := newWriterFile(t, oh, verify)
+	wf.flushAfterEveryWrite = flushAfterEveryWrite
+	wf.sidecar = sidecarHandle(bh, object)
+	return uintptr(cgo.NewHandle(wf))
+}
+
+//export GoStorageResumeWriteonly
+func GoStorageResumeWriteonly(td uintptr, filenameCstr *C.char, offset int64, verify bool, flushAfterEveryWrite bool) uintptr {
+	filename := C.GoString(filenameCstr)
+	slog.Debug("go storage resume writeonly", "td", td, "filename", filename, "offset", offset)
+	t, bh, object, err := filenameBucketObjectHandle(td, filename)
+	if err != nil {
+		slog.Error("resume: error getting *storage.ObjectHandle", "err", err)
+		return 0
+	}
+	oh := bh.Object(object)
+	sidecar := sidecarHandle(bh, object)
+
+	size, err := getObjectSize(oh)
+	if err != nil {
+		slog.Error("resume: failed to get object size", "filename", filename, "err", err)
+		return 0
+	}
+	if state, err := readUploadState(sidecar); err != nil {
+		slog.Error("resume: failed to read upload state", "filename", filename, "err", err)
+		return 0
+	} else if state != nil && state.Offset > size {
+		size = state.Offset
+	}
+	if offset != size {
+		slog.Error("resume: requested offset does not match checkpoint", "filename", filename, "offset", offset, "objectSize", size)
+		return 0
+	}
+	// A resumed writerFile's crc32cHash only accumulates the newly written
+	// bytes, but GCS validates Writer.CRC32C against the entire object (the
+	// bytes from before the resume plus the new ones) - seeding the hash
+	// from the already-written prefix isn't worth it for a benchmark tool,
+	// so verify on resume is rejected rather than always failing at Close.
+	if verify {
+		slog.Error("resume: verify is not supported when resuming a write session", "filename", filename)
+		return 0
+	}
+
+	wf := newWriterFile(t, oh, verify)
+	wf.flushAfterEveryWrite = flushAfterEveryWrite
+	wf.sidecar = sidecar
+	wf.offset = offset
+	return uintptr(cgo.NewHandle(wf))
+}
+
+//export GoStorageAbortWrite
+func GoStorageAbortWrite(v uintptr) bool {
+	slog.Debug("go storage abort write", "handle", v)
+	f, h, ok := handle[goFile](v)
+	if !ok {
+		return false
+	}
+	h.Delete()
+	w, ok := f.(*writerFile)
+	if !ok {
+		slog.Error("abort: handle is not a writerFile", "v", v)
+		return false
+	}
+	if err := w.Close(); err != nil {
+		slog.Error("abort: close error (swallowing)", "err", err)
+	}
+	if w.sidecar == nil {
+		return true
+	}
+	if err := w.sidecar.Delete(context.Background()); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		slog.Error("abort: failed to delete upload-state sidecar", "err", err)
+		return false
+	}
+	return true
 }
 
 //export GoStorageClose
@@ -262,24 +694,140 @@ func (m *mrdFile) Close() error {
 	return m.mrd.Close()
 }
 
+// mrdReadResult is a single range read's outcome, tagged with enough
+// information to feed verify/metrics regardless of whether it came from the
+// primary read or a hedge read racing it.
+type mrdReadResult struct {
+	buf       *bytes.Buffer
+	prefixLen int
+	length    int64
+	err       error
+	hedged    bool
+}
+
 func (m *mrdFile) enqueue(p []byte, offset int64, tag unsafe.Pointer) int {
-	buf := bytes.NewBuffer(p)
-	m.mrd.Add(buf, offset, int64(len(p)), func(offset, length int64, err error) {
-		m.completions <- iouCompletion{tag, err}
+	start := time.Now()
+	inFlightOpsInc()
+
+	// results always receives exactly one message from the primary read,
+	// plus exactly one more from the hedge goroutine if hedging is enabled
+	// (even when the hedge never actually issues a duplicate read) - see
+	// runHedge. That makes draining the loser below race-free.
+	results := make(chan mrdReadResult, 2)
+
+	primaryBuf := bytes.NewBuffer(p)
+	m.mrd.Add(primaryBuf, offset, int64(len(p)), func(offset, length int64, err error) {
+		results <- mrdReadResult{buf: primaryBuf, prefixLen: len(p), length: length, err: err}
 	})
+
+	var hedgeCancel context.CancelFunc
+	if m.hedge != nil {
+		hedgeCancel = m.runHedge(offset, int64(len(p)), results)
+	}
+
+	go func() {
+		defer inFlightOpsDec()
+		res := <-results
+		if hedgeCancel != nil {
+			hedgeCancel()
+		}
+		if m.latency != nil && !res.hedged {
+			m.latency.observe(time.Since(start))
+		}
+		verifyFailure := false
+		if res.err == nil && m.verify.observe(offset, res.buf.Bytes()[res.prefixLen:]) {
+			res.err = fmt.Errorf("%w at offset %d", errChecksumMismatch, offset)
+			verifyFailure = true
+		}
+		observeOp(opKindMRDRead, start, int(res.length))
+		m.completions <- iouCompletion{iou: tag, err: res.err, verifyFailure: verifyFailure}
+
+		if m.hedge != nil {
+			<-results // discard the loser
+		}
+	}()
 	return fioQQueued
 }
 
+// runHedge waits for the configured hedge delay and, unless cancelled first,
+// issues a duplicate range read on a second MultiRangeDownloader racing the
+// primary. It always sends exactly one mrdReadResult to results - even if it
+// never actually issues a read - so the caller can unconditionally drain one
+// extra message once it has delivered the winner. The returned cancel func
+// aborts an in-flight or not-yet-started hedge attempt.
+func (m *mrdFile) runHedge(offset, length int64, results chan<- mrdReadResult) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-time.After(m.hedge.delayFor(m.latency)):
+		case <-ctx.Done():
+			results <- mrdReadResult{hedged: true, err: ctx.Err()}
+			return
+		}
+		hedgeMRD, err := m.oh.NewMultiRangeDownloader(ctx)
+		if err != nil {
+			results <- mrdReadResult{hedged: true, err: err}
+			return
+		}
+		hedgeBuf := bytes.NewBuffer(nil)
+		hedgeMRD.Add(hedgeBuf, offset, length, func(offset, length int64, err error) {
+			defer hedgeMRD.Close()
+			results <- mrdReadResult{buf: hedgeBuf, length: length, err: err, hedged: true}
+		})
+	}()
+	return cancel
+}
+
 func (o *oDirectMrdFile) Close() error {
+	if o.pool != nil {
+		return o.pool.close()
+	}
 	return nil
 }
 
 func (o *oDirectMrdFile) enqueue(p []byte, offset int64, tag unsafe.Pointer) int {
+	if o.perOpStream {
+		return o.enqueuePerOpStream(p, offset, tag)
+	}
+
+	prefixLen := len(p)
+	start := time.Now()
+	inFlightOpsInc()
+	mrd, err := o.pool.acquire()
+	if err != nil {
+		inFlightOpsDec()
+		slog.Error("failed MRD open for pooled O_DIRECT enqueue", "err", err)
+		o.completions <- iouCompletion{iou: tag, err: err}
+		return fioQQueued
+	}
+	buf := bytes.NewBuffer(p)
+	mrd.Add(buf, offset, int64(len(p)), func(offset, length int64, err error) {
+		defer inFlightOpsDec()
+		o.pool.release()
+		observeOp(opKindODirectMRDRead, start, int(length))
+		verifyFailure := false
+		if err == nil && o.verify.observe(offset, buf.Bytes()[prefixLen:]) {
+			err = fmt.Errorf("%w at offset %d", errChecksumMismatch, offset)
+			verifyFailure = true
+		}
+		o.completions <- iouCompletion{iou: tag, err: err, verifyFailure: verifyFailure}
+	})
+	return fioQQueued
+}
+
+// enqueuePerOpStream implements the historical "fresh MultiRangeDownloader
+// per op" behavior, selected via the oDirectPerOpStream open option so the
+// pooled path above can be A/B benchmarked against it.
+func (o *oDirectMrdFile) enqueuePerOpStream(p []byte, offset int64, tag unsafe.Pointer) int {
+	prefixLen := len(p)
+	start := time.Now()
+	inFlightOpsInc()
 	go func() {
+		defer inFlightOpsDec()
 		mrd, err := o.oh.NewMultiRangeDownloader(context.Background())
 		if err != nil {
 			slog.Error("failed MRD open for O_DIRECT enqueue", "err", err)
-			o.completions <- iouCompletion{tag, err}
+			o.completions <- iouCompletion{iou: tag, err: err}
 			return
 		}
 		buf := bytes.NewBuffer(p)
@@ -291,29 +839,67 @@ func (o *oDirectMrdFile) enqueue(p []byte, offset int64, tag unsafe.Pointer) int
 		if err := mrd.Close(); err != nil {
 			addErr = fmt.Errorf("read error: %w; close error: %w", addErr, err)
 		}
-		o.completions <- iouCompletion{tag, addErr}
+		observeOp(opKindODirectMRDRead, start, len(p))
+		verifyFailure := false
+		if addErr == nil && o.verify.observe(offset, buf.Bytes()[prefixLen:]) {
+			addErr = fmt.Errorf("%w at offset %d", errChecksumMismatch, offset)
+			verifyFailure = true
+		}
+		o.completions <- iouCompletion{iou: tag, err: addErr, verifyFailure: verifyFailure}
 	}()
 	return fioQQueued
 }
 
 func (w *writerFile) Close() error {
-	return w.w.Close()
+	if w.verify {
+		w.w.CRC32C = w.crc32cHash
+	}
+	err := w.w.Close()
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.releaseUploadSlot != nil {
+		w.releaseUploadSlot()
+	}
+	return err
 }
 
 func (w *writerFile) enqueue(p []byte, offset int64, tag unsafe.Pointer) int {
-	if _, err := w.w.Write(p); err != nil {
+	start := time.Now()
+	n, err := w.w.Write(p)
+	if err != nil {
 		slog.Error("write error", "err", err)
 		return -1
 	}
+	w.offset += int64(n)
+	if w.verify {
+		w.crc32cHash = crc32.Update(w.crc32cHash, crc32cTable, p[:n])
+	}
+	observeOp(opKindAppendWrite, start, n)
 	if w.flushAfterEveryWrite {
+		flushStart := time.Now()
 		if _, err := w.w.Flush(); err != nil {
 			slog.Error("flush error", "err", err)
 			return -1
 		}
+		observeOp(opKindFlush, flushStart, 0)
+		if err := w.checkpoint(); err != nil {
+			slog.Error("checkpoint error", "err", err)
+			return -1
+		}
 	}
 	return fioQCompleted
 }
 
+// checkpoint persists the current append offset to the sidecar object so a
+// later GoStorageResumeWriteonly call can pick this session back up.
+func (w *writerFile) checkpoint() error {
+	if w.sidecar == nil {
+		return nil
+	}
+	return writeUploadState(w.sidecar, uploadState{Offset: w.offset})
+}
+
 func getObjectSize(oh *storage.ObjectHandle) (int64, error) {
 	attrs, err := oh.Attrs(context.Background())
 	if errors.Is(err, storage.ErrObjectNotExist) {
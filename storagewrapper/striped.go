@@ -0,0 +1,306 @@
+// Copyright 2025 Google LLC
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import "C"
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/cgo"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"cloud.google.com/go/storage"
+	"github.com/klauspost/reedsolomon"
+)
+
+// stripedLayout is the parsed form of a GoStorageOpenStriped layout spec,
+// "<dataShards>+<parityShards>@<stripeSize>", e.g. "4+2@1048576" for 4 data
+// shards, 2 parity shards, and 1 MiB per shard per stripe (so each stripe
+// holds 4 MiB of logical data). stripeSize is the per-shard size.
+type stripedLayout struct {
+	dataShards   int
+	parityShards int
+	stripeSize   int64
+}
+
+func parseStripedLayout(spec string) (stripedLayout, error) {
+	shardSpec, sizeSpec, ok := strings.Cut(spec, "@")
+	if !ok {
+		return stripedLayout{}, fmt.Errorf("striped layout %q missing '@stripeSize'", spec)
+	}
+	dataSpec, paritySpec, ok := strings.Cut(shardSpec, "+")
+	if !ok {
+		return stripedLayout{}, fmt.Errorf("striped layout %q missing 'data+parity'", spec)
+	}
+	n, err := strconv.Atoi(dataSpec)
+	if err != nil {
+		return stripedLayout{}, fmt.Errorf("striped layout %q: bad data shard count: %w", spec, err)
+	}
+	m, err := strconv.Atoi(paritySpec)
+	if err != nil {
+		return stripedLayout{}, fmt.Errorf("striped layout %q: bad parity shard count: %w", spec, err)
+	}
+	stripeSize, err := strconv.ParseInt(sizeSpec, 10, 64)
+	if err != nil {
+		return stripedLayout{}, fmt.Errorf("striped layout %q: bad stripe size: %w", spec, err)
+	}
+	if n <= 0 || m <= 0 || stripeSize <= 0 {
+		return stripedLayout{}, fmt.Errorf("striped layout %q: data/parity/stripeSize must be positive", spec)
+	}
+	return stripedLayout{dataShards: n, parityShards: m, stripeSize: stripeSize}, nil
+}
+
+// shardNames returns the sibling object names for a striped file: <name>.d0
+// .. <name>.d<N-1> for data, <name>.p0 .. <name>.p<M-1> for parity.
+func (l stripedLayout) shardNames(baseName string) []string {
+	names := make([]string, 0, l.dataShards+l.parityShards)
+	for i := 0; i < l.dataShards; i++ {
+		names = append(names, fmt.Sprintf("%s.d%d", baseName, i))
+	}
+	for i := 0; i < l.parityShards; i++ {
+		names = append(names, fmt.Sprintf("%s.p%d", baseName, i))
+	}
+	return names
+}
+
+// stripedFile is a goFile that spreads a logical file across N data shards
+// and M Reed-Solomon parity shards stored as sibling objects. A single
+// instance is either a reader or a writer, selected at open time, mirroring
+// mrdFile/writerFile rather than multiplexing both directions through one
+// enqueue.
+type stripedFile struct {
+	completions chan<- iouCompletion
+	layout      stripedLayout
+	enc         reedsolomon.Encoder
+	shards      []*storage.ObjectHandle // data shards first, then parity
+	write       bool
+
+	writers []*storage.Writer // lazily opened, append-mode, one per shard; write mode only
+}
+
+//export GoStorageOpenStriped
+func GoStorageOpenStriped(td uintptr, layoutSpecCstr *C.char, filenameCstr *C.char, write bool) uintptr {
+	layoutSpec := C.GoString(layoutSpecCstr)
+	filename := C.GoString(filenameCstr)
+	slog.Debug("go storage open striped", "td", td, "layout", layoutSpec, "filename", filename, "write", write)
+
+	layout, err := parseStripedLayout(layoutSpec)
+	if err != nil {
+		slog.Error("open striped: bad layout spec", "err", err)
+		return 0
+	}
+	enc, err := reedsolomon.New(layout.dataShards, layout.parityShards)
+	if err != nil {
+		slog.Error("open striped: failed to build Reed-Solomon encoder", "err", err)
+		return 0
+	}
+
+	t, bh, object, err := filenameBucketObjectHandle(td, filename)
+	if err != nil {
+		slog.Error("open striped: error getting bucket handle", "err", err)
+		return 0
+	}
+
+	shards := make([]*storage.ObjectHandle, 0, layout.dataShards+layout.parityShards)
+	for _, name := range layout.shardNames(object) {
+		shards = append(shards, bh.Object(name))
+	}
+
+	f := &stripedFile{
+		completions: t.completions,
+		layout:      layout,
+		enc:         enc,
+		shards:      shards,
+		write:       write,
+	}
+	if write {
+		f.writers = make([]*storage.Writer, len(shards))
+	}
+	return uintptr(cgo.NewHandle(f))
+}
+
+func (s *stripedFile) Close() error {
+	var errs []error
+	for _, w := range s.writers {
+		if w == nil {
+			continue
+		}
+		if err := w.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *stripedFile) stripeSizeBytes() int64 {
+	return int64(s.layout.dataShards) * s.layout.stripeSize
+}
+
+// enqueue treats p as exactly one stripe's worth of logical bytes: callers
+// are expected to size writes to dataShards*stripeSize and align reads to
+// stripeSizeBytes() boundaries, per the layout spec. Writes encode p into
+// data+parity shards and append all of them in parallel; reads fetch the
+// data and parity shards for the stripe in parallel and reconstruct the data
+// from parity if any of them fails.
+func (s *stripedFile) enqueue(p []byte, offset int64, tag unsafe.Pointer) int {
+	stripeIndex := offset / s.stripeSizeBytes()
+	if s.write {
+		return s.enqueueWrite(p, stripeIndex)
+	}
+	go s.enqueueRead(p, stripeIndex, tag)
+	return fioQQueued
+}
+
+func (s *stripedFile) enqueueWrite(p []byte, stripeIndex int64) int {
+	shards := s.splitIntoShards(p)
+	if err := s.enc.Encode(shards); err != nil {
+		slog.Error("striped write: failed to encode parity", "err", err)
+		return -1
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(shards))
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard []byte) {
+			defer wg.Done()
+			w := s.writerFor(i)
+			if _, err := w.Write(shard); err != nil {
+				errs[i] = err
+				return
+			}
+			errs[i] = w.Flush()
+		}(i, shard)
+	}
+	wg.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	// Tolerate up to parityShards failed shards per stripe, same budget the
+	// read path reconstructs against.
+	if len(failed) > s.layout.parityShards {
+		slog.Error("striped write: too many shard failures", "failed", len(failed), "tolerable", s.layout.parityShards)
+		return -1
+	}
+	return fioQCompleted
+}
+
+// writerFor lazily opens the append writer for shard i. Only ever called
+// from enqueueWrite's per-stripe goroutines for distinct i, so it doesn't
+// need its own locking.
+func (s *stripedFile) writerFor(shard int) *storage.Writer {
+	if s.writers[shard] != nil {
+		return s.writers[shard]
+	}
+	w := s.shards[shard].Retryer(storage.WithPolicy(storage.RetryAlways)).NewWriter(context.Background())
+	w.Append = true
+	s.writers[shard] = w
+	return w
+}
+
+// splitIntoShards slices p into dataShards equal-size pieces (zero-filling
+// whatever part of them p does not cover if p is short) and returns a dataShards+parityShards slice
+// ready for enc.Encode to fill the parity entries of.
+func (s *stripedFile) splitIntoShards(p []byte) [][]byte {
+	n := s.layout.dataShards
+	shardSize := int(s.layout.stripeSize)
+	shards := make([][]byte, n+s.layout.parityShards)
+	for i := 0; i < n; i++ {
+		start := i * shardSize
+		shard := make([]byte, shardSize)
+		if start < len(p) {
+			end := start + shardSize
+			if end > len(p) {
+				end = len(p)
+			}
+			copy(shard, p[start:end])
+		}
+		shards[i] = shard
+	}
+	for i := n; i < len(shards); i++ {
+		shards[i] = make([]byte, shardSize)
+	}
+	return shards
+}
+
+func (s *stripedFile) enqueueRead(p []byte, stripeIndex int64, tag unsafe.Pointer) {
+	_, err := s.readStripe(stripeIndex)
+	if err != nil {
+		slog.Error("striped read failed", "stripeIndex", stripeIndex, "err", err)
+	}
+	s.completions <- iouCompletion{iou: tag, err: err}
+}
+
+// readStripe fetches all data and parity shards of stripeIndex in parallel
+// and returns the data shards, reconstructing from parity if up to
+// parityShards of the reads fail.
+func (s *stripedFile) readStripe(stripeIndex int64) ([][]byte, error) {
+	n := s.layout.dataShards
+	shardSize := s.layout.stripeSize
+	shardOffset := stripeIndex * shardSize
+
+	shards := make([][]byte, n+s.layout.parityShards)
+	var wg sync.WaitGroup
+	for i := 0; i < len(shards); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data, err := s.readShardRange(i, shardOffset, shardSize)
+			if err != nil {
+				slog.Debug("striped read: shard unavailable, will try to reconstruct", "shard", i, "err", err)
+				return
+			}
+			shards[i] = data
+		}(i)
+	}
+	wg.Wait()
+
+	missing := 0
+	for _, shard := range shards {
+		if shard == nil {
+			missing++
+		}
+	}
+	if missing == 0 {
+		return shards[:n], nil
+	}
+	if missing > s.layout.parityShards {
+		return nil, fmt.Errorf("striped read: %d shards missing, only %d parity shards available", missing, s.layout.parityShards)
+	}
+	if err := s.enc.Reconstruct(shards); err != nil {
+		return nil, fmt.Errorf("striped read: reconstruction failed: %w", err)
+	}
+	return shards[:n], nil
+}
+
+func (s *stripedFile) readShardRange(shard int, offset, length int64) ([]byte, error) {
+	mrd, err := s.shards[shard].NewMultiRangeDownloader(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer mrd.Close()
+
+	var buf bytes.Buffer
+	errs := make(chan error, 1)
+	mrd.Add(&buf, offset, length, func(offset, length int64, err error) {
+		errs <- err
+	})
+	if err := <-errs; err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
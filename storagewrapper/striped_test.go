@@ -0,0 +1,63 @@
+// Copyright 2025 Google LLC
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file or at
+// https://opensource.org/licenses/MIT.
+
+package main
+
+import "testing"
+
+func TestParseStripedLayout(t *testing.T) {
+	cases := []struct {
+		name    string
+		spec    string
+		want    stripedLayout
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			spec: "4+2@1048576",
+			want: stripedLayout{dataShards: 4, parityShards: 2, stripeSize: 1048576},
+		},
+		{name: "missing stripe size", spec: "4+2", wantErr: true},
+		{name: "missing parity", spec: "4@1048576", wantErr: true},
+		{name: "non-numeric data shards", spec: "x+2@1048576", wantErr: true},
+		{name: "non-numeric parity shards", spec: "4+x@1048576", wantErr: true},
+		{name: "non-numeric stripe size", spec: "4+2@x", wantErr: true},
+		{name: "zero data shards", spec: "0+2@1048576", wantErr: true},
+		{name: "zero parity shards", spec: "4+0@1048576", wantErr: true},
+		{name: "zero stripe size", spec: "4+2@0", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := parseStripedLayout(c.spec)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("parseStripedLayout(%q) = %+v, nil, want error", c.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseStripedLayout(%q) returned unexpected error: %v", c.spec, err)
+			}
+			if got != c.want {
+				t.Fatalf("parseStripedLayout(%q) = %+v, want %+v", c.spec, got, c.want)
+			}
+		})
+	}
+}
+
+func TestStripedLayoutShardNames(t *testing.T) {
+	l := stripedLayout{dataShards: 2, parityShards: 1}
+	want := []string{"obj.d0", "obj.d1", "obj.p0"}
+	got := l.shardNames("obj")
+	if len(got) != len(want) {
+		t.Fatalf("shardNames() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("shardNames()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}